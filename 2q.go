@@ -0,0 +1,227 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/InFocusGames/golang-lru/simplelru"
+)
+
+const (
+	// Default2QRecentRatio is the ratio of the cache size used for
+	// recently accessed items
+	Default2QRecentRatio = 0.25
+
+	// Default2QGhostEntries is the default ratio of ghost
+	// entries kept to track entries recently evicted
+	Default2QGhostEntries = 0.50
+)
+
+// TwoQueueCache is a thread-safe fixed size 2Q cache.
+// 2Q is an enhancement over the standard LRU cache
+// in that it tracks both frequently and recently used
+// entries separately. This avoids a burst in access to new
+// entries from evicting frequently used entries. It adds some
+// additional tracking overhead to the standard LRU cache, and is
+// computationally about 2x the cost, and adds some metadata over
+// head. It builds on top of the weighted simplelru.LRU, so entries
+// added with an *Option weight carry that weight through promotion
+// between the recent and frequent queues.
+type TwoQueueCache struct {
+	size       int
+	recentSize int
+
+	recent      *simplelru.LRU
+	frequent    *simplelru.LRU
+	recentEvict *simplelru.LRU
+	lock        sync.RWMutex
+}
+
+// New2Q creates a new TwoQueueCache using the default
+// values for the parameters.
+func New2Q(size int) (*TwoQueueCache, error) {
+	return New2QParams(size, Default2QRecentRatio, Default2QGhostEntries)
+}
+
+// New2QParams creates a new TwoQueueCache using the provided
+// parameter values.
+func New2QParams(size int, recentRatio float64, ghostRatio float64) (*TwoQueueCache, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid size")
+	}
+	if recentRatio < 0.0 || recentRatio > 1.0 {
+		return nil, fmt.Errorf("invalid recent ratio")
+	}
+	if ghostRatio < 0.0 || ghostRatio > 1.0 {
+		return nil, fmt.Errorf("invalid ghost ratio")
+	}
+
+	// Determine the sub-sizes
+	recentSize := int(float64(size) * recentRatio)
+	evictSize := int(float64(size) * ghostRatio)
+	if evictSize == 0 {
+		evictSize = 1
+	}
+
+	// Allocate the LRUs
+	recent, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	frequent, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	recentEvict, err := simplelru.NewLRU(evictSize, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &TwoQueueCache{
+		size:        size,
+		recentSize:  recentSize,
+		recent:      recent,
+		frequent:    frequent,
+		recentEvict: recentEvict,
+	}
+	return c, nil
+}
+
+// Get looks up a key's value from the cache.
+func (c *TwoQueueCache) Get(key interface{}) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Check if this is a frequent value
+	if val, ok := c.frequent.Get(key); ok {
+		return val, ok
+	}
+
+	// If the value is contained in recent, then we
+	// promote it to frequent, carrying its weight along so a
+	// Get-triggered promotion doesn't quietly reset it to 1
+	if val, weight, ok := c.recent.PeekWithWeight(key); ok {
+		c.recent.Remove(key)
+		c.frequent.Add(key, val, &Option{Weight: weight})
+		return val, ok
+	}
+
+	return nil, false
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *TwoQueueCache) Add(key, value interface{}, opt *Option) (error, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Check if the value is frequently used already, and just
+	// update the value
+	if c.frequent.Contains(key) {
+		_, evicted := c.frequent.Add(key, value, opt)
+		return nil, evicted
+	}
+
+	// Check if the value is recently used, and promote
+	// the value into the frequent list
+	if c.recent.Contains(key) {
+		c.recent.Remove(key)
+		_, evicted := c.frequent.Add(key, value, opt)
+		return nil, evicted
+	}
+
+	// If the value was recently evicted, add it to the
+	// frequently used list, as this is a second hit
+	if c.recentEvict.Contains(key) {
+		spaceEvicted := c.ensureSpace(true)
+		c.recentEvict.Remove(key)
+		_, evicted := c.frequent.Add(key, value, opt)
+		return nil, spaceEvicted || evicted
+	}
+
+	// Add to the recently seen list
+	spaceEvicted := c.ensureSpace(false)
+	_, evicted := c.recent.Add(key, value, opt)
+	return nil, spaceEvicted || evicted
+}
+
+// ensureSpace is used to ensure we have space in the cache. It returns
+// true if an entry was evicted from recent or frequent to make room.
+func (c *TwoQueueCache) ensureSpace(recentEvict bool) bool {
+	// If we have space, nothing to do
+	recentLen := c.recent.Len()
+	freqLen := c.frequent.Len()
+	if recentLen+freqLen < c.size {
+		return false
+	}
+
+	// If the recent buffer is larger than the target, evict from
+	// there, moving the key to the ghost list
+	if recentLen > 0 && (recentLen > c.recentSize || (recentLen == c.recentSize && !recentEvict)) {
+		k, _, _ := c.recent.RemoveOldest()
+		c.recentEvict.Add(k, nil, nil)
+		return true
+	}
+
+	// Remove from the frequent list otherwise
+	_, _, ok := c.frequent.RemoveOldest()
+	return ok
+}
+
+// Contains checks if a key is in the cache, without updating
+// recency or frequency.
+func (c *TwoQueueCache) Contains(key interface{}) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.frequent.Contains(key) || c.recent.Contains(key)
+}
+
+// Peek returns the key value without updating recency or frequency.
+func (c *TwoQueueCache) Peek(key interface{}) (value interface{}, ok bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if val, ok := c.frequent.Peek(key); ok {
+		return val, ok
+	}
+	return c.recent.Peek(key)
+}
+
+// Remove removes a key from the cache.
+func (c *TwoQueueCache) Remove(key interface{}) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.frequent.Remove(key) {
+		return true
+	}
+	if c.recent.Remove(key) {
+		return true
+	}
+	if c.recentEvict.Remove(key) {
+		return true
+	}
+	return false
+}
+
+// Keys returns a slice of the keys in the cache.
+func (c *TwoQueueCache) Keys() []interface{} {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	k1 := c.frequent.Keys()
+	k2 := c.recent.Keys()
+	return append(k1, k2...)
+}
+
+// Len returns the number of items in the cache.
+func (c *TwoQueueCache) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.recent.Len() + c.frequent.Len()
+}
+
+// Purge is used to completely clear the cache.
+func (c *TwoQueueCache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.recent.Purge()
+	c.frequent.Purge()
+	c.recentEvict.Purge()
+}
@@ -0,0 +1,59 @@
+package lru
+
+import "time"
+
+// cacheJanitor periodically sweeps expired entries out of a Cache in the
+// background. Unlike simplelru.LRU, Cache already has a lock, so driving
+// DeleteExpired from a goroutine here is safe against concurrent callers.
+type cacheJanitor struct {
+	interval time.Duration
+	stopCh   chan struct{}
+}
+
+func newCacheJanitor(c *Cache, interval time.Duration) *cacheJanitor {
+	j := &cacheJanitor{
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+	go j.run(c)
+	return j
+}
+
+func (j *cacheJanitor) run(c *Cache) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-j.stopCh:
+			return
+		}
+	}
+}
+
+func (j *cacheJanitor) stop() {
+	close(j.stopCh)
+}
+
+// NewCacheWithJanitor constructs a Cache that also starts a background
+// goroutine calling DeleteExpired every interval, on top of the lazy
+// expiry Get/Peek/Contains already perform. Call Stop to shut the
+// goroutine down once the cache is no longer needed.
+func NewCacheWithJanitor(size int, interval time.Duration, onEvict EvictCallback) (*Cache, error) {
+	c, err := NewWithEvict(size, onEvict)
+	if err != nil {
+		return nil, err
+	}
+	c.janitor = newCacheJanitor(c, interval)
+	return c, nil
+}
+
+// Stop shuts down the background janitor goroutine started by
+// NewCacheWithJanitor. It is a no-op for a Cache built with New or
+// NewWithEvict.
+func (c *Cache) Stop() {
+	if c.janitor != nil {
+		c.janitor.stop()
+	}
+}
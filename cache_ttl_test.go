@@ -0,0 +1,54 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache_AddWithTTL_Expires(t *testing.T) {
+	c, err := New(128)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	c.AddWithTTL("a", 1, time.Millisecond, nil)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be expired")
+	}
+}
+
+func TestCache_DeleteExpired(t *testing.T) {
+	c, err := New(128)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	c.AddWithTTL("a", 1, time.Millisecond, nil)
+	c.Add("b", 2, nil)
+	time.Sleep(5 * time.Millisecond)
+	c.DeleteExpired()
+
+	if c.Len() != 1 {
+		t.Fatalf("expected only b to remain, len=%v", c.Len())
+	}
+}
+
+func TestCache_NewCacheWithJanitor(t *testing.T) {
+	c, err := NewCacheWithJanitor(128, 2*time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer c.Stop()
+
+	c.AddWithTTL("a", 1, time.Millisecond, nil)
+
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for c.Len() != 0 && time.Now().Before(deadline) {
+		time.Sleep(2 * time.Millisecond)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("expected janitor to sweep expired entry, len=%v", c.Len())
+	}
+}
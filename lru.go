@@ -0,0 +1,128 @@
+// Package lru provides thread-safe caches in front of the eviction
+// policies implemented in the simplelru subpackage.
+package lru
+
+import (
+	"sync"
+	"time"
+
+	"github.com/InFocusGames/golang-lru/simplelru"
+)
+
+// Option is used to specify options for adding a new entry, such as its
+// weight.
+type Option = simplelru.Option
+
+// EvictCallback is used to get a callback when a cache entry is evicted
+type EvictCallback simplelru.EvictCallback
+
+// Cache is a thread-safe fixed size LRU cache.
+type Cache struct {
+	lru     *simplelru.LRU
+	lock    sync.RWMutex
+	janitor *cacheJanitor
+}
+
+// New creates an LRU of the given size.
+func New(size int) (*Cache, error) {
+	return NewWithEvict(size, nil)
+}
+
+// NewWithEvict constructs a new Cache with the given onEvict callback.
+func NewWithEvict(size int, onEvict EvictCallback) (*Cache, error) {
+	lru, err := simplelru.NewLRU(size, simplelru.EvictCallback(onEvict))
+	if err != nil {
+		return nil, err
+	}
+	c := &Cache{lru: lru}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache.
+func (c *Cache) Purge() {
+	c.lock.Lock()
+	c.lru.Purge()
+	c.lock.Unlock()
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *Cache) Add(key, value interface{}, opt *Option) (error, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Add(key, value, opt)
+}
+
+// AddWithTTL adds a value to the cache that is treated as absent once
+// ttl has elapsed. A non-positive ttl behaves like Add: the entry never
+// expires. Returns true if an eviction occurred.
+func (c *Cache) AddWithTTL(key, value interface{}, ttl time.Duration, opt *Option) (error, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.AddWithTTL(key, value, ttl, opt)
+}
+
+// DeleteExpired removes every entry whose TTL has elapsed, firing the
+// cache's onEvict callback for each one. Get/Peek/Contains already expire
+// entries lazily on access, so DeleteExpired is only needed to reclaim
+// space for keys that are never looked up again; see NewCacheWithJanitor
+// to do that automatically.
+func (c *Cache) DeleteExpired() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lru.DeleteExpired()
+}
+
+// Get looks up a key's value from the cache.
+func (c *Cache) Get(key interface{}) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Get(key)
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness. Contains takes the full
+// lock, not RLock, because a TTL'd entry found expired is removed from
+// the underlying simplelru.LRU as a side effect.
+func (c *Cache) Contains(key interface{}) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Contains(key)
+}
+
+// Peek returns the key value (or undefined if not found) without
+// updating the "recently used"-ness of the key. Peek takes the full
+// lock, not RLock, because a TTL'd entry found expired is removed from
+// the underlying simplelru.LRU as a side effect.
+func (c *Cache) Peek(key interface{}) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Peek(key)
+}
+
+// Remove removes the provided key from the cache.
+func (c *Cache) Remove(key interface{}) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Remove(key)
+}
+
+// RemoveOldest removes the oldest item from the cache.
+func (c *Cache) RemoveOldest() (interface{}, interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.RemoveOldest()
+}
+
+// Keys returns a slice of the keys in the cache, from oldest to newest.
+func (c *Cache) Keys() []interface{} {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Keys()
+}
+
+// Len returns the number of items in the cache.
+func (c *Cache) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,89 @@
+package lru
+
+import (
+	"sync"
+
+	"github.com/InFocusGames/golang-lru/simplelru"
+)
+
+// CacheG is a thread-safe fixed size LRU cache parameterized over its key
+// and value types, for callers migrating off the interface{}-based Cache.
+type CacheG[K comparable, V any] struct {
+	lru  *simplelru.LRUG[K, V]
+	lock sync.RWMutex
+}
+
+// NewG creates an LRU of the given size, migrated to generics.
+func NewG[K comparable, V any](size int, onEvict simplelru.EvictCallbackG[K, V]) (*CacheG[K, V], error) {
+	lru, err := simplelru.NewLRUG[K, V](size, onEvict)
+	if err != nil {
+		return nil, err
+	}
+	c := &CacheG[K, V]{lru: lru}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache.
+func (c *CacheG[K, V]) Purge() {
+	c.lock.Lock()
+	c.lru.Purge()
+	c.lock.Unlock()
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *CacheG[K, V]) Add(key K, value V, opt *Option) (error, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Add(key, value, opt)
+}
+
+// Get looks up a key's value from the cache.
+func (c *CacheG[K, V]) Get(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Get(key)
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness or deleting it for being stale.
+func (c *CacheG[K, V]) Contains(key K) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Contains(key)
+}
+
+// Peek returns the key value (or the zero value if not found) without
+// updating the "recently used"-ness of the key.
+func (c *CacheG[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Peek(key)
+}
+
+// Remove removes the provided key from the cache.
+func (c *CacheG[K, V]) Remove(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Remove(key)
+}
+
+// RemoveOldest removes the oldest item from the cache.
+func (c *CacheG[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.RemoveOldest()
+}
+
+// Keys returns a slice of the keys in the cache, from oldest to newest.
+func (c *CacheG[K, V]) Keys() []K {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Keys()
+}
+
+// Len returns the number of items in the cache.
+func (c *CacheG[K, V]) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Len()
+}
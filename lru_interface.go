@@ -0,0 +1,37 @@
+package lru
+
+// LRUCache is the common interface implemented by all the caches in this
+// package (Cache, SieveCache, ...), so callers can swap the eviction
+// policy backing a cache without changing call sites.
+type LRUCache interface {
+	// Add adds a value to the cache, returns true if an eviction occurred
+	// and updates the "recently used"-ness of the key.
+	Add(key, value interface{}, opt *Option) (error, bool)
+
+	// Get returns key's value from the cache and updates the
+	// "recently used"-ness of the key.
+	Get(key interface{}) (value interface{}, ok bool)
+
+	// Contains checks if a key exists in cache without updating the
+	// recent-ness.
+	Contains(key interface{}) (ok bool)
+
+	// Peek returns key's value without updating the "recently used"-ness
+	// of the key.
+	Peek(key interface{}) (value interface{}, ok bool)
+
+	// Remove removes a key from the cache.
+	Remove(key interface{}) bool
+
+	// Keys returns a slice of the keys in the cache. The order depends on
+	// the implementation: Cache and SieveCache return oldest to newest,
+	// TwoQueueCache returns the frequent keys before the recent ones, and
+	// ShardedCache returns the keys shard by shard.
+	Keys() []interface{}
+
+	// Len returns the number of items in the cache.
+	Len() int
+
+	// Purge clears all cache entries.
+	Purge()
+}
@@ -0,0 +1,198 @@
+package lru
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+
+	"github.com/InFocusGames/golang-lru/simplelru"
+)
+
+// ShardedCache fans keys out across a fixed number of independently
+// locked shards, each one a plain simplelru.LRU. Unlike Cache, which
+// serializes every Add/Get behind a single mutex, hot keys that land on
+// different shards don't contend with each other, which matters once the
+// cache sits on a request hot path under GOMAXPROCS > 1.
+type ShardedCache struct {
+	shards []*shard
+	mask   uint64
+	hasher func(key interface{}) uint64
+}
+
+type shard struct {
+	lru  *simplelru.LRU
+	lock sync.RWMutex
+}
+
+// ShardedOption configures a ShardedCache at construction time.
+type ShardedOption func(*shardedConfig)
+
+type shardedConfig struct {
+	hasher func(key interface{}) uint64
+}
+
+// WithHasher overrides the function used to route a key to its shard.
+// The default hasher (see defaultHasher) handles strings and byte slices
+// with FNV-1a and falls back to reflection for everything else.
+func WithHasher(hasher func(key interface{}) uint64) ShardedOption {
+	return func(cfg *shardedConfig) {
+		cfg.hasher = hasher
+	}
+}
+
+// NewSharded creates a ShardedCache with the given number of shards,
+// distributing size roughly evenly across them. shards is rounded up to
+// the next power of two so a key can be routed with a mask instead of a
+// modulo; each shard's capacity is rounded up too, so the aggregate
+// capacity is always >= size.
+func NewSharded(size, shards int, onEvict EvictCallback, opts ...ShardedOption) (*ShardedCache, error) {
+	if size <= 0 {
+		return nil, simplelru.ErrInvalidLRUSize
+	}
+	if shards <= 0 {
+		return nil, fmt.Errorf("invalid shard count")
+	}
+
+	cfg := shardedConfig{hasher: defaultHasher}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	n := nextPowerOfTwo(shards)
+	perShard := (size + n - 1) / n
+
+	c := &ShardedCache{
+		shards: make([]*shard, n),
+		mask:   uint64(n - 1),
+		hasher: cfg.hasher,
+	}
+	for i := range c.shards {
+		l, err := simplelru.NewLRU(perShard, simplelru.EvictCallback(onEvict))
+		if err != nil {
+			return nil, err
+		}
+		c.shards[i] = &shard{lru: l}
+	}
+	return c, nil
+}
+
+// defaultHasher hashes strings and byte slices with FNV-1a, and falls
+// back to reflection for every other comparable key type: integer kinds
+// are used as the hash value directly, and anything else is formatted
+// with %v and that text is hashed with FNV-1a.
+func defaultHasher(key interface{}) uint64 {
+	switch k := key.(type) {
+	case string:
+		h := fnv.New64a()
+		h.Write([]byte(k))
+		return h.Sum64()
+	case []byte:
+		h := fnv.New64a()
+		h.Write(k)
+		return h.Sum64()
+	default:
+		v := reflect.ValueOf(key)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return uint64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return v.Uint()
+		default:
+			h := fnv.New64a()
+			fmt.Fprintf(h, "%v", key)
+			return h.Sum64()
+		}
+	}
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+func (c *ShardedCache) shardFor(key interface{}) *shard {
+	return c.shards[c.hasher(key)&c.mask]
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *ShardedCache) Add(key, value interface{}, opt *Option) (error, bool) {
+	s := c.shardFor(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lru.Add(key, value, opt)
+}
+
+// Get looks up a key's value from the cache.
+func (c *ShardedCache) Get(key interface{}) (value interface{}, ok bool) {
+	s := c.shardFor(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lru.Get(key)
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness. Contains takes the full
+// lock, not RLock, because a TTL'd entry found expired is removed from
+// the underlying simplelru.LRU as a side effect.
+func (c *ShardedCache) Contains(key interface{}) bool {
+	s := c.shardFor(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lru.Contains(key)
+}
+
+// Peek returns the key value (or undefined if not found) without
+// updating the "recently used"-ness of the key. Peek takes the full
+// lock, not RLock, because a TTL'd entry found expired is removed from
+// the underlying simplelru.LRU as a side effect.
+func (c *ShardedCache) Peek(key interface{}) (value interface{}, ok bool) {
+	s := c.shardFor(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lru.Peek(key)
+}
+
+// Remove removes the provided key from the cache.
+func (c *ShardedCache) Remove(key interface{}) bool {
+	s := c.shardFor(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lru.Remove(key)
+}
+
+// Keys returns a slice of the keys in the cache. Because each shard is
+// locked and drained independently, the result is not a single
+// point-in-time snapshot of the whole cache under concurrent writers.
+func (c *ShardedCache) Keys() []interface{} {
+	var keys []interface{}
+	for _, s := range c.shards {
+		s.lock.RLock()
+		keys = append(keys, s.lru.Keys()...)
+		s.lock.RUnlock()
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache.
+func (c *ShardedCache) Len() int {
+	total := 0
+	for _, s := range c.shards {
+		s.lock.RLock()
+		total += s.lru.Len()
+		s.lock.RUnlock()
+	}
+	return total
+}
+
+// Purge is used to completely clear the cache.
+func (c *ShardedCache) Purge() {
+	for _, s := range c.shards {
+		s.lock.Lock()
+		s.lru.Purge()
+		s.lock.Unlock()
+	}
+}
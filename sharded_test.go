@@ -0,0 +1,65 @@
+package lru
+
+import "testing"
+
+func TestSharded_RoundsShardsUpToPowerOfTwo(t *testing.T) {
+	c, err := NewSharded(128, 5, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(c.shards) != 8 {
+		t.Fatalf("expected 5 shards to round up to 8, got %d", len(c.shards))
+	}
+}
+
+func TestSharded_AddGetRemove(t *testing.T) {
+	c, err := NewSharded(128, 8, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		c.Add(i, i, nil)
+	}
+	if c.Len() != 100 {
+		t.Fatalf("bad len: %v", c.Len())
+	}
+
+	for i := 0; i < 100; i++ {
+		v, ok := c.Get(i)
+		if !ok || v != i {
+			t.Fatalf("bad get for %d: %v, %v", i, v, ok)
+		}
+	}
+
+	if !c.Remove(0) {
+		t.Fatalf("expected 0 to be removed")
+	}
+	if c.Contains(0) {
+		t.Fatalf("expected 0 to be gone")
+	}
+
+	c.Purge()
+	if c.Len() != 0 {
+		t.Fatalf("bad len after purge: %v", c.Len())
+	}
+}
+
+func TestSharded_WithHasher(t *testing.T) {
+	calls := 0
+	hasher := func(key interface{}) uint64 {
+		calls++
+		return 0
+	}
+
+	c, err := NewSharded(128, 4, nil, WithHasher(hasher))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	c.Add("a", 1, nil)
+	c.Get("a")
+	if calls == 0 {
+		t.Fatalf("expected custom hasher to be used")
+	}
+}
@@ -0,0 +1,89 @@
+package lru
+
+import (
+	"sync"
+
+	"github.com/InFocusGames/golang-lru/simplelru"
+)
+
+// SieveCache is a thread-safe fixed size cache using the SIEVE eviction
+// algorithm. Prefer it over Cache on read-heavy, skewed-popularity
+// workloads, since SIEVE's Get never has to touch the list.
+type SieveCache struct {
+	sieve *simplelru.SIEVE
+	lock  sync.RWMutex
+}
+
+// NewSIEVECache constructs a SieveCache of the given size.
+func NewSIEVECache(size int) (*SieveCache, error) {
+	return NewSIEVECacheWithEvict(size, nil)
+}
+
+// NewSIEVECacheWithEvict constructs a new SieveCache with the given
+// onEvict callback.
+func NewSIEVECacheWithEvict(size int, onEvict EvictCallback) (*SieveCache, error) {
+	sieve, err := simplelru.NewSIEVE(size, simplelru.EvictCallback(onEvict))
+	if err != nil {
+		return nil, err
+	}
+	c := &SieveCache{sieve: sieve}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache.
+func (c *SieveCache) Purge() {
+	c.lock.Lock()
+	c.sieve.Purge()
+	c.lock.Unlock()
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *SieveCache) Add(key, value interface{}, opt *Option) (error, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sieve.Add(key, value, opt)
+}
+
+// Get looks up a key's value from the cache and marks it as visited.
+func (c *SieveCache) Get(key interface{}) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sieve.Get(key)
+}
+
+// Contains checks if a key is in the cache, without marking it as
+// visited or deleting it for being stale.
+func (c *SieveCache) Contains(key interface{}) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.sieve.Contains(key)
+}
+
+// Peek returns the key value (or undefined if not found) without
+// marking the key as visited.
+func (c *SieveCache) Peek(key interface{}) (value interface{}, ok bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.sieve.Peek(key)
+}
+
+// Remove removes the provided key from the cache.
+func (c *SieveCache) Remove(key interface{}) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sieve.Remove(key)
+}
+
+// Keys returns a slice of the keys in the cache, in insertion order.
+func (c *SieveCache) Keys() []interface{} {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.sieve.Keys()
+}
+
+// Len returns the number of items in the cache.
+func (c *SieveCache) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.sieve.Len()
+}
@@ -3,6 +3,7 @@ package simplelru
 import (
 	"container/list"
 	"errors"
+	"time"
 )
 
 var (
@@ -24,9 +25,15 @@ type LRU struct {
 
 // entry is used to hold a value in the evictList
 type entry struct {
-	key    interface{}
-	value  interface{}
-	weight int
+	key      interface{}
+	value    interface{}
+	weight   int
+	expireAt int64 // UnixNano deadline; zero means the entry never expires
+}
+
+// expired reports whether the entry's TTL has elapsed.
+func (e *entry) expired() bool {
+	return e.expireAt != 0 && e.expireAt <= time.Now().UnixNano()
 }
 
 // option is used to specify options for adding the new entry.
@@ -48,20 +55,33 @@ func NewLRU(size int, onEvict EvictCallback) (*LRU, error) {
 	return c, nil
 }
 
-// Purge is used to completely clear the cache
+// Purge is used to completely clear the cache. It swaps in a fresh
+// evictList/items, which is O(1) regardless of how many entries the
+// cache holds: it does not walk the map and does not fire onEvict for
+// any of the discarded entries.
 func (c *LRU) Purge() {
-	for k, v := range c.items {
-		if c.onEvict != nil {
-			c.onEvict(k, v.Value.(*entry).value)
-		}
-		delete(c.items, k)
-	}
-	c.evictList.Init()
+	c.evictList = list.New()
+	c.items = make(map[interface{}]*list.Element)
 	c.used = 0
 }
 
 // Add adds a value to the cache.  Returns true if an eviction occurred.
 func (c *LRU) Add(key, value interface{}, opt *Option) (error, bool) {
+	return c.addEntry(key, value, opt, 0)
+}
+
+// AddWithTTL adds a value to the cache that is treated as absent once ttl
+// has elapsed. A non-positive ttl behaves like Add: the entry never
+// expires. Returns true if an eviction occurred.
+func (c *LRU) AddWithTTL(key, value interface{}, ttl time.Duration, opt *Option) (error, bool) {
+	var expireAt int64
+	if ttl > 0 {
+		expireAt = time.Now().Add(ttl).UnixNano()
+	}
+	return c.addEntry(key, value, opt, expireAt)
+}
+
+func (c *LRU) addEntry(key, value interface{}, opt *Option, expireAt int64) (error, bool) {
 	// Check for existing item
 	var weight int = 1
 	if opt != nil && opt.Weight != 0 {
@@ -75,7 +95,7 @@ func (c *LRU) Add(key, value interface{}, opt *Option) (error, bool) {
 		c.evictList.MoveToFront(ent)
 		original := ent.Value.(*entry)
 		c.used = c.used - original.weight + weight
-		ent.Value.(*entry).value, ent.Value.(*entry).weight = value, weight
+		original.value, original.weight, original.expireAt = value, weight, expireAt
 		evict := c.used > c.size
 		if evict {
 			c.removeOldest()
@@ -84,9 +104,9 @@ func (c *LRU) Add(key, value interface{}, opt *Option) (error, bool) {
 	}
 
 	// Add new item
-	ent := &entry{key, value, weight}
-	entry := c.evictList.PushFront(ent)
-	c.items[key] = entry
+	ent := &entry{key: key, value: value, weight: weight, expireAt: expireAt}
+	element := c.evictList.PushFront(ent)
+	c.items[key] = element
 	c.used += ent.weight
 
 	evict := c.used > c.size
@@ -100,8 +120,13 @@ func (c *LRU) Add(key, value interface{}, opt *Option) (error, bool) {
 // Get looks up a key's value from the cache.
 func (c *LRU) Get(key interface{}) (value interface{}, ok bool) {
 	if ent, ok := c.items[key]; ok {
+		e := ent.Value.(*entry)
+		if e.expired() {
+			c.removeElement(ent)
+			return nil, false
+		}
 		c.evictList.MoveToFront(ent)
-		return ent.Value.(*entry).value, true
+		return e.value, true
 	}
 	return
 }
@@ -109,19 +134,48 @@ func (c *LRU) Get(key interface{}) (value interface{}, ok bool) {
 // Check if a key is in the cache, without updating the recent-ness
 // or deleting it for being stale.
 func (c *LRU) Contains(key interface{}) (ok bool) {
-	_, ok = c.items[key]
-	return ok
+	ent, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	e := ent.Value.(*entry)
+	if e.expired() {
+		c.removeElement(ent)
+		return false
+	}
+	return true
 }
 
 // Returns the key value (or undefined if not found) without updating
 // the "recently used"-ness of the key.
 func (c *LRU) Peek(key interface{}) (value interface{}, ok bool) {
 	if ent, ok := c.items[key]; ok {
-		return ent.Value.(*entry).value, true
+		e := ent.Value.(*entry)
+		if e.expired() {
+			c.removeElement(ent)
+			return nil, false
+		}
+		return e.value, true
 	}
 	return nil, ok
 }
 
+// PeekWithWeight is Peek plus the weight the entry was last added or
+// updated with, so callers that move an entry into another weighted LRU
+// (e.g. TwoQueueCache promoting recent into frequent) can carry the
+// weight along instead of silently resetting it to the default of 1.
+func (c *LRU) PeekWithWeight(key interface{}) (value interface{}, weight int, ok bool) {
+	if ent, ok := c.items[key]; ok {
+		e := ent.Value.(*entry)
+		if e.expired() {
+			c.removeElement(ent)
+			return nil, 0, false
+		}
+		return e.value, e.weight, true
+	}
+	return nil, 0, false
+}
+
 // Remove removes the provided key from the cache, returning if the
 // key was contained.
 func (c *LRU) Remove(key interface{}) bool {
@@ -169,6 +223,25 @@ func (c *LRU) Len() int {
 	return c.evictList.Len()
 }
 
+// DeleteExpired removes every entry whose TTL has elapsed, firing
+// onEvict for each one. Get/Peek/Contains already expire entries lazily
+// on access, so DeleteExpired is only needed to reclaim space for keys
+// that are never looked up again, or to drive expiry off a timer.
+// LRU itself is not thread-safe, so callers that want a background
+// sweeper should drive DeleteExpired from the locked layer that wraps
+// this type (see lru.NewCacheWithJanitor) rather than from a bare
+// goroutine racing against LRU's own callers.
+func (c *LRU) DeleteExpired() {
+	now := time.Now().UnixNano()
+	for ent := c.evictList.Back(); ent != nil; {
+		prev := ent.Prev()
+		if e := ent.Value.(*entry); e.expireAt != 0 && e.expireAt <= now {
+			c.removeElement(ent)
+		}
+		ent = prev
+	}
+}
+
 // removeOldest removes the oldest item from the cache.
 func (c *LRU) removeOldest() {
 	for c.used > c.size {
@@ -0,0 +1,183 @@
+package simplelru
+
+import (
+	"container/list"
+)
+
+// EvictCallbackG is used to get a callback when a cache entry is evicted
+// from an LRUG. It is the generic counterpart of EvictCallback.
+type EvictCallbackG[K comparable, V any] func(key K, value V)
+
+// entryG is used to hold a value in the evictList. Unlike entry, it holds
+// real K/V fields instead of interface{}, so keys and values themselves
+// are never boxed. container/list.Element.Value is still interface{},
+// so every access still does a single ent.Value.(*entryG[K, V]) type
+// assertion, the same as entry does for LRU.
+type entryG[K comparable, V any] struct {
+	key    K
+	value  V
+	weight int
+}
+
+// LRUG implements a non-thread safe fixed size LRU cache, parameterized
+// over its key and value types.
+type LRUG[K comparable, V any] struct {
+	size      int
+	used      int
+	evictList *list.List
+	items     map[K]*list.Element
+	onEvict   EvictCallbackG[K, V]
+}
+
+// NewLRUG constructs an LRUG of the given size
+func NewLRUG[K comparable, V any](size int, onEvict EvictCallbackG[K, V]) (*LRUG[K, V], error) {
+	if size <= 0 {
+		return nil, ErrInvalidLRUSize
+	}
+	c := &LRUG[K, V]{
+		size:      size,
+		evictList: list.New(),
+		items:     make(map[K]*list.Element),
+		onEvict:   onEvict,
+	}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache
+func (c *LRUG[K, V]) Purge() {
+	for k, v := range c.items {
+		if c.onEvict != nil {
+			c.onEvict(k, v.Value.(*entryG[K, V]).value)
+		}
+		delete(c.items, k)
+	}
+	c.evictList.Init()
+	c.used = 0
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *LRUG[K, V]) Add(key K, value V, opt *Option) (error, bool) {
+	var weight int = 1
+	if opt != nil && opt.Weight != 0 {
+		weight = opt.Weight
+	}
+
+	if weight > c.size {
+		return ErrTooLargeWeight, false
+	}
+	if ent, ok := c.items[key]; ok {
+		c.evictList.MoveToFront(ent)
+		original := ent.Value.(*entryG[K, V])
+		c.used = c.used - original.weight + weight
+		original.value, original.weight = value, weight
+		evict := c.used > c.size
+		if evict {
+			c.removeOldest()
+		}
+		return nil, evict
+	}
+
+	ent := &entryG[K, V]{key: key, value: value, weight: weight}
+	element := c.evictList.PushFront(ent)
+	c.items[key] = element
+	c.used += weight
+
+	evict := c.used > c.size
+	if evict {
+		c.removeOldest()
+	}
+	return nil, evict
+}
+
+// Get looks up a key's value from the cache.
+func (c *LRUG[K, V]) Get(key K) (value V, ok bool) {
+	if ent, ok := c.items[key]; ok {
+		c.evictList.MoveToFront(ent)
+		return ent.Value.(*entryG[K, V]).value, true
+	}
+	return value, false
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness or deleting it for being stale.
+func (c *LRUG[K, V]) Contains(key K) (ok bool) {
+	_, ok = c.items[key]
+	return ok
+}
+
+// Peek returns the key value (or the zero value if not found) without
+// updating the "recently used"-ness of the key.
+func (c *LRUG[K, V]) Peek(key K) (value V, ok bool) {
+	if ent, ok := c.items[key]; ok {
+		return ent.Value.(*entryG[K, V]).value, true
+	}
+	return value, false
+}
+
+// Remove removes the provided key from the cache, returning if the
+// key was contained.
+func (c *LRUG[K, V]) Remove(key K) bool {
+	if ent, ok := c.items[key]; ok {
+		c.removeElement(ent)
+		return true
+	}
+	return false
+}
+
+// RemoveOldest removes the oldest item from the cache.
+func (c *LRUG[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	ent := c.evictList.Back()
+	if ent != nil {
+		c.removeElement(ent)
+		kv := ent.Value.(*entryG[K, V])
+		return kv.key, kv.value, true
+	}
+	return key, value, false
+}
+
+// GetOldest returns the oldest entry
+func (c *LRUG[K, V]) GetOldest() (key K, value V, ok bool) {
+	ent := c.evictList.Back()
+	if ent != nil {
+		kv := ent.Value.(*entryG[K, V])
+		return kv.key, kv.value, true
+	}
+	return key, value, false
+}
+
+// Keys returns a slice of the keys in the cache, from oldest to newest.
+func (c *LRUG[K, V]) Keys() []K {
+	keys := make([]K, len(c.items))
+	i := 0
+	for ent := c.evictList.Back(); ent != nil; ent = ent.Prev() {
+		keys[i] = ent.Value.(*entryG[K, V]).key
+		i++
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache.
+func (c *LRUG[K, V]) Len() int {
+	return c.evictList.Len()
+}
+
+// removeOldest removes the oldest item from the cache.
+func (c *LRUG[K, V]) removeOldest() {
+	for c.used > c.size {
+		ent := c.evictList.Back()
+		if ent != nil {
+			c.removeElement(ent)
+		}
+	}
+}
+
+// removeElement is used to remove a given list element from the cache
+func (c *LRUG[K, V]) removeElement(e *list.Element) {
+	c.evictList.Remove(e)
+	kv := e.Value.(*entryG[K, V])
+	delete(c.items, kv.key)
+	if c.onEvict != nil {
+		c.onEvict(kv.key, kv.value)
+	}
+	c.used -= kv.weight
+}
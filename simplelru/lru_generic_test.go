@@ -0,0 +1,116 @@
+package simplelru
+
+import "testing"
+
+func TestLRUG(t *testing.T) {
+	evictCounter := 0
+	onEvicted := func(k int, v int) {
+		if k != v {
+			t.Fatalf("Evict values not equal (%v!=%v)", k, v)
+		}
+		evictCounter += 1
+	}
+	l, err := NewLRUG[int, int](128, onEvicted)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for i := 0; i < 256; i++ {
+		l.Add(i, i, nil)
+	}
+	if l.Len() != 128 {
+		t.Fatalf("bad len: %v", l.Len())
+	}
+	if evictCounter != 128 {
+		t.Fatalf("bad evict count: %v", evictCounter)
+	}
+
+	for i, k := range l.Keys() {
+		if v, ok := l.Get(k); !ok || v != k || v != i+128 {
+			t.Fatalf("bad key: %v", k)
+		}
+	}
+	for i := 0; i < 128; i++ {
+		_, ok := l.Get(i)
+		if ok {
+			t.Fatalf("should be evicted")
+		}
+	}
+	for i := 128; i < 256; i++ {
+		_, ok := l.Get(i)
+		if !ok {
+			t.Fatalf("should not be evicted")
+		}
+	}
+
+	l.Purge()
+	if l.Len() != 0 {
+		t.Fatalf("bad len: %v", l.Len())
+	}
+	if _, ok := l.Get(200); ok {
+		t.Fatalf("should contain nothing")
+	}
+}
+
+func TestLRUG_GetOldest_RemoveOldest(t *testing.T) {
+	l, err := NewLRUG[int, int](128, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	for i := 0; i < 256; i++ {
+		l.Add(i, i, nil)
+	}
+	k, _, ok := l.GetOldest()
+	if !ok {
+		t.Fatalf("missing")
+	}
+	if k != 128 {
+		t.Fatalf("bad: %v", k)
+	}
+
+	k, _, ok = l.RemoveOldest()
+	if !ok {
+		t.Fatalf("missing")
+	}
+	if k != 128 {
+		t.Fatalf("bad: %v", k)
+	}
+}
+
+func TestLRUG_Weighted(t *testing.T) {
+	l, err := NewLRUG[string, string](20, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for i := 1; i <= 20; i++ {
+		l.Add("k", "v", &Option{i})
+	}
+	if l.Len() != 1 {
+		t.Fatalf("bad len: %v", l.Len())
+	}
+
+	err, _ = l.Add("k", "v", &Option{1000})
+	if err != ErrTooLargeWeight {
+		t.Fatalf("error should be returned if the weight is too high")
+	}
+}
+
+// Test that Contains doesn't update recent-ness
+func TestLRUG_Contains(t *testing.T) {
+	l, err := NewLRUG[int, int](2, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	l.Add(1, 1, nil)
+	l.Add(2, 2, nil)
+	if !l.Contains(1) {
+		t.Fatalf("1 should be contained")
+	}
+
+	l.Add(3, 3, nil)
+	if l.Contains(1) {
+		t.Fatalf("Contains should not have updated recent-ness of 1")
+	}
+}
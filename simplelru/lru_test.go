@@ -192,3 +192,19 @@ func TestLRU_Peek(t *testing.T) {
 		t.Errorf("should not have updated recent-ness of 1")
 	}
 }
+
+// Test that PeekWithWeight reports the weight an entry was added with
+func TestLRU_PeekWithWeight(t *testing.T) {
+	l, err := NewLRU(128, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	l.Add(1, 1, &Option{Weight: 50})
+	if v, weight, ok := l.PeekWithWeight(1); !ok || v != 1 || weight != 50 {
+		t.Errorf("expected 1 with weight 50: %v, %v, %v", v, weight, ok)
+	}
+	if _, _, ok := l.PeekWithWeight(2); ok {
+		t.Errorf("2 should not be contained")
+	}
+}
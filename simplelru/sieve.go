@@ -0,0 +1,187 @@
+package simplelru
+
+import (
+	"container/list"
+)
+
+// SIEVE implements a non-thread safe fixed size cache using the SIEVE
+// eviction algorithm. SIEVE has been shown to reach higher hit ratios than
+// LRU, 2Q and ARC on several skewed, real-world traces while being cheaper
+// to maintain: Get never moves list elements, it only flips a bit.
+type SIEVE struct {
+	size      int
+	used      int
+	evictList *list.List
+	items     map[interface{}]*list.Element
+	hand      *list.Element
+	onEvict   EvictCallback
+}
+
+// sieveEntry is used to hold a value in the evictList
+type sieveEntry struct {
+	key     interface{}
+	value   interface{}
+	weight  int
+	visited bool
+}
+
+// NewSIEVE constructs a SIEVE of the given size
+func NewSIEVE(size int, onEvict EvictCallback) (*SIEVE, error) {
+	if size <= 0 {
+		return nil, ErrInvalidLRUSize
+	}
+	c := &SIEVE{
+		size:      size,
+		evictList: list.New(),
+		items:     make(map[interface{}]*list.Element),
+		onEvict:   onEvict,
+	}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache
+func (c *SIEVE) Purge() {
+	for k, v := range c.items {
+		if c.onEvict != nil {
+			c.onEvict(k, v.Value.(*sieveEntry).value)
+		}
+		delete(c.items, k)
+	}
+	c.evictList.Init()
+	c.hand = nil
+	c.used = 0
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *SIEVE) Add(key, value interface{}, opt *Option) (error, bool) {
+	var weight int = 1
+	if opt != nil && opt.Weight != 0 {
+		weight = opt.Weight
+	}
+
+	if weight > c.size {
+		return ErrTooLargeWeight, false
+	}
+
+	if ent, ok := c.items[key]; ok {
+		e := ent.Value.(*sieveEntry)
+		c.used = c.used - e.weight + weight
+		e.value, e.weight, e.visited = value, weight, true
+		evict := c.used > c.size
+		if evict {
+			c.evict()
+		}
+		return nil, evict
+	}
+
+	// New entries always start at the head, unvisited.
+	ent := &sieveEntry{key: key, value: value, weight: weight}
+	element := c.evictList.PushFront(ent)
+	c.items[key] = element
+	c.used += weight
+
+	evict := c.used > c.size
+	if evict {
+		c.evict()
+	}
+	return nil, evict
+}
+
+// Get looks up a key's value from the cache and marks it as visited.
+func (c *SIEVE) Get(key interface{}) (value interface{}, ok bool) {
+	if ent, ok := c.items[key]; ok {
+		e := ent.Value.(*sieveEntry)
+		e.visited = true
+		return e.value, true
+	}
+	return
+}
+
+// Contains checks if a key is in the cache, without marking it as
+// visited or deleting it for being stale.
+func (c *SIEVE) Contains(key interface{}) (ok bool) {
+	_, ok = c.items[key]
+	return ok
+}
+
+// Peek returns the key value (or undefined if not found) without
+// marking the key as visited.
+func (c *SIEVE) Peek(key interface{}) (value interface{}, ok bool) {
+	if ent, ok := c.items[key]; ok {
+		return ent.Value.(*sieveEntry).value, true
+	}
+	return nil, ok
+}
+
+// Remove removes the provided key from the cache, returning if the
+// key was contained.
+func (c *SIEVE) Remove(key interface{}) bool {
+	if ent, ok := c.items[key]; ok {
+		c.removeElement(ent)
+		return true
+	}
+	return false
+}
+
+// Keys returns a slice of the keys in the cache, in insertion order
+// (oldest to newest), regardless of visited state.
+func (c *SIEVE) Keys() []interface{} {
+	keys := make([]interface{}, len(c.items))
+	i := 0
+	for ent := c.evictList.Back(); ent != nil; ent = ent.Prev() {
+		keys[i] = ent.Value.(*sieveEntry).key
+		i++
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache.
+func (c *SIEVE) Len() int {
+	return c.evictList.Len()
+}
+
+// evict runs the SIEVE hand, scanning from tail toward head, until enough
+// weight has been reclaimed to bring the cache back under its size limit.
+// An entry with visited == true is spared once (its flag is cleared and
+// the hand advances); an entry with visited == false is evicted and the
+// hand is left at its predecessor. The hand wraps to the tail once it
+// walks off the head.
+func (c *SIEVE) evict() {
+	for c.used > c.size {
+		hand := c.hand
+		if hand == nil {
+			hand = c.evictList.Back()
+		}
+		if hand == nil {
+			return
+		}
+
+		e := hand.Value.(*sieveEntry)
+		if e.visited {
+			e.visited = false
+			hand = hand.Prev()
+			if hand == nil {
+				hand = c.evictList.Back()
+			}
+			c.hand = hand
+			continue
+		}
+
+		c.hand = hand.Prev()
+		c.removeElement(hand)
+	}
+}
+
+// removeElement is used to remove a given list element from the cache
+func (c *SIEVE) removeElement(e *list.Element) {
+	if c.hand == e {
+		c.hand = e.Prev()
+	}
+	c.evictList.Remove(e)
+	kv := e.Value.(*sieveEntry)
+	delete(c.items, kv.key)
+	if c.onEvict != nil {
+		c.onEvict(kv.key, kv.value)
+	}
+	c.used -= kv.weight
+}
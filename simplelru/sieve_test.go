@@ -0,0 +1,111 @@
+package simplelru
+
+import "testing"
+
+func TestSIEVE(t *testing.T) {
+	evictCounter := 0
+	onEvicted := func(k interface{}, v interface{}) {
+		if k != v {
+			t.Fatalf("Evict values not equal (%v!=%v)", k, v)
+		}
+		evictCounter += 1
+	}
+	l, err := NewSIEVE(128, onEvicted)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for i := 0; i < 256; i++ {
+		l.Add(i, i, nil)
+	}
+	if l.Len() != 128 {
+		t.Fatalf("bad len: %v", l.Len())
+	}
+	if evictCounter != 128 {
+		t.Fatalf("bad evict count: %v", evictCounter)
+	}
+
+	for i := 0; i < 128; i++ {
+		_, ok := l.Get(i)
+		if ok {
+			t.Fatalf("should be evicted")
+		}
+	}
+	for i := 128; i < 256; i++ {
+		_, ok := l.Get(i)
+		if !ok {
+			t.Fatalf("should not be evicted")
+		}
+	}
+
+	l.Purge()
+	if l.Len() != 0 {
+		t.Fatalf("bad len: %v", l.Len())
+	}
+	if l.used != 0 {
+		t.Fatalf("bad used: %v", l.used)
+	}
+}
+
+func TestSIEVE_VisitedSurvivesOneSweep(t *testing.T) {
+	l, err := NewSIEVE(2, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	l.Add(1, 1, nil)
+	l.Add(2, 2, nil)
+	l.Get(1) // mark 1 as visited so it is spared on the next eviction
+
+	l.Add(3, 3, nil)
+	if !l.Contains(1) {
+		t.Fatalf("visited entry should have survived the sweep")
+	}
+	if l.Contains(2) {
+		t.Fatalf("unvisited entry should have been evicted")
+	}
+}
+
+func TestSIEVE_Weighted(t *testing.T) {
+	l, err := NewSIEVE(128, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for i := 1; i <= 20; i++ {
+		l.Add(i, i, &Option{i})
+	}
+	if l.Len() != 7 {
+		t.Fatalf("expect to contain the last 7 elements, got %v", l.Len())
+	}
+
+	err, _ = l.Add(20, 20, &Option{1000})
+	if err != ErrTooLargeWeight {
+		t.Fatalf("error should be returned if the weight is too high")
+	}
+}
+
+func TestSIEVE_Contains(t *testing.T) {
+	l, err := NewSIEVE(2, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	l.Add(1, 1, nil)
+	l.Add(2, 2, nil)
+	if !l.Contains(1) {
+		t.Fatalf("1 should be contained")
+	}
+}
+
+func TestSIEVE_Peek(t *testing.T) {
+	l, err := NewSIEVE(2, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	l.Add(1, 1, nil)
+	if v, ok := l.Peek(1); !ok || v != 1 {
+		t.Fatalf("1 should be set to 1: %v, %v", v, ok)
+	}
+}
@@ -0,0 +1,88 @@
+package simplelru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU_AddWithTTL_Expires(t *testing.T) {
+	l, err := NewLRU(128, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	l.AddWithTTL("a", 1, time.Millisecond, nil)
+	if v, ok := l.Peek("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1 before expiry, got %v, %v", v, ok)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := l.Get("a"); ok {
+		t.Fatalf("expected a to be expired")
+	}
+	if l.Contains("a") {
+		t.Fatalf("expected a to be gone after expiry")
+	}
+}
+
+func TestLRU_AddWithTTL_ZeroMeansNoExpiry(t *testing.T) {
+	l, err := NewLRU(128, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	l.AddWithTTL("a", 1, 0, nil)
+	time.Sleep(2 * time.Millisecond)
+	if _, ok := l.Get("a"); !ok {
+		t.Fatalf("expected a to still be present")
+	}
+}
+
+func TestLRU_DeleteExpired(t *testing.T) {
+	evicted := make(map[interface{}]bool)
+	onEvict := func(k, v interface{}) {
+		evicted[k] = true
+	}
+	l, err := NewLRU(128, onEvict)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	l.AddWithTTL("a", 1, time.Millisecond, nil)
+	l.Add("b", 2, nil)
+
+	time.Sleep(5 * time.Millisecond)
+	l.DeleteExpired()
+
+	if l.Len() != 1 {
+		t.Fatalf("expected only b to remain, len=%v", l.Len())
+	}
+	if !evicted["a"] {
+		t.Fatalf("expected onEvict to fire for expired key a")
+	}
+	if _, ok := l.Get("b"); !ok {
+		t.Fatalf("expected b to be unaffected")
+	}
+}
+
+func TestLRU_Purge_O1(t *testing.T) {
+	l, err := NewLRU(128, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.Add(i, i, nil)
+	}
+	l.Purge()
+
+	if l.Len() != 0 {
+		t.Fatalf("bad len: %v", l.Len())
+	}
+
+	l.Add(0, "new", nil)
+	if v, ok := l.Get(0); !ok || v != "new" {
+		t.Fatalf("expected fresh entry after purge, got %v, %v", v, ok)
+	}
+}